@@ -0,0 +1,42 @@
+package when
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runableapp/when-ng/rules"
+)
+
+func TestParseScannedFastPathResolvesAmbiguousDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	p := New(&rules.Options{
+		Distance:            5,
+		MatchByOrder:        true,
+		DefaultLocation:     loc,
+		AmbiguousTimePolicy: rules.PolicyLatest,
+	})
+
+	res, err := p.Parse("2024-11-03 01:30", time.Now())
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if res == nil {
+		t.Fatal("Parse returned nil result")
+	}
+	if !res.Ambiguous {
+		t.Fatal("Ambiguous = false, want true: the scanner fast path must resolve DST like the rule path does")
+	}
+	if res.Zone == nil {
+		t.Fatal("Zone is nil, want America/New_York's resolved offset")
+	}
+	if res.Alternative.IsZero() {
+		t.Fatal("Alternative is zero, want the instant PolicyLatest didn't pick")
+	}
+	if res.Time.Minute() != 30 {
+		t.Fatalf("Minute = %d, want 30", res.Time.Minute())
+	}
+}
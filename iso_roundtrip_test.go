@@ -0,0 +1,42 @@
+package when
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runableapp/when-ng/rules"
+	"github.com/runableapp/when-ng/rules/common"
+)
+
+// TestParseISORoundTrip exercises the claim in rules/common/iso_date.go that
+// result.Time.Format(time.RFC3339Nano) fed back into Parse always round-
+// trips, now that the scanner fast path (runableapp/when-ng#chunk0-1,
+// runableapp/when-ng#chunk0-3) no longer corrupts the plain non-fractional
+// case it used to claim.
+func TestParseISORoundTrip(t *testing.T) {
+	p := New(nil)
+	p.Add(common.ISODate(rules.Default))
+
+	cases := []string{
+		"2020-05-22T15:55:30Z",
+		"2020-05-22t15:55:30.123456z",
+	}
+
+	for _, text := range cases {
+		res, err := p.Parse(text, time.Now())
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", text, err)
+		}
+		if res == nil {
+			t.Fatalf("Parse(%q) returned nil result", text)
+		}
+
+		again, err := p.Parse(res.Time.Format(time.RFC3339Nano), time.Now())
+		if err != nil {
+			t.Fatalf("Parse(round-trip of %q): %v", text, err)
+		}
+		if again == nil || !again.Time.Equal(res.Time) {
+			t.Fatalf("round-trip of %q: got %v, want %v", text, again, res.Time)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package when
+
+import (
+	"strings"
+	"time"
+)
+
+// defaultLayouts is the shared, precedence-ordered registry of time.Parse
+// layouts consulted by tryRegisteredLayouts, as a last resort after rule-
+// based matching has had a chance to claim the text. RegisterLayout appends
+// to it for every Parser; Parser.AddLayout layers parser-local layouts on
+// top of it, and Parser.RemoveLayout lets a parser opt out of one it
+// doesn't want.
+var defaultLayouts []string
+
+// RegisterLayout adds layout to the shared registry consulted by every
+// Parser, in insertion order. Registering the same layout twice is a no-op.
+func RegisterLayout(layout string) {
+	for _, l := range defaultLayouts {
+		if l == layout {
+			return
+		}
+	}
+	defaultLayouts = append(defaultLayouts, layout)
+}
+
+func init() {
+	RegisterLayout(time.RFC1123Z)
+	RegisterLayout(time.RFC1123)
+	RegisterLayout(time.RFC822Z)
+	RegisterLayout(time.RFC822)
+	RegisterLayout(time.ANSIC)
+	RegisterLayout(time.UnixDate)
+	RegisterLayout(time.RubyDate)
+	RegisterLayout("Mon Jan 2 15:04:05 2006")
+}
+
+// AddLayout registers additional time.Parse layouts consulted only by this
+// Parser, in the order given and ahead of the shared registry.
+func (p *Parser) AddLayout(layout ...string) {
+	p.layouts = append(p.layouts, layout...)
+}
+
+// RemoveLayout stops this Parser from trying the given layouts from the
+// shared registry. Layouts added via AddLayout are unaffected.
+func (p *Parser) RemoveLayout(layout ...string) {
+	if p.removedLayouts == nil {
+		p.removedLayouts = make(map[string]bool, len(layout))
+	}
+	for _, l := range layout {
+		p.removedLayouts[l] = true
+	}
+}
+
+// layoutsFor returns the layouts this Parser consults, in precedence order:
+// its own first, then the shared registry minus anything it removed.
+func (p *Parser) layoutsFor() []string {
+	layouts := make([]string, 0, len(p.layouts)+len(defaultLayouts))
+	layouts = append(layouts, p.layouts...)
+	for _, l := range defaultLayouts {
+		if !p.removedLayouts[l] {
+			layouts = append(layouts, l)
+		}
+	}
+	return layouts
+}
+
+// AddStrftime registers format as a strftime-style layout (as used by
+// Python's datetime or PostgreSQL's to_char), converting its directives to
+// the equivalent Go reference-time layout before adding it.
+func (p *Parser) AddStrftime(format string) {
+	p.AddLayout(strftimeToLayout(format))
+}
+
+var strftimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'd': "02",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'z': "-0700",
+	'Z': "MST",
+	'b': "Jan",
+	'B': "January",
+	'a': "Mon",
+	'A': "Monday",
+}
+
+// strftimeToLayout rewrites the %-directives of a strftime format string
+// into their Go reference-time equivalents, leaving anything it doesn't
+// recognize untouched.
+func strftimeToLayout(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] == '%' && i+1 < len(format) {
+			if layout, ok := strftimeDirectives[format[i+1]]; ok {
+				b.WriteString(layout)
+				i++
+				continue
+			}
+		}
+		b.WriteByte(format[i])
+	}
+	return b.String()
+}
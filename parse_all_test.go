@@ -0,0 +1,31 @@
+package when
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runableapp/when-ng/rules"
+	"github.com/runableapp/when-ng/rules/common"
+)
+
+func TestParseAllReturnsEveryCluster(t *testing.T) {
+	p := New(nil)
+	p.Add(common.ISODate(rules.Default))
+
+	base := time.Now()
+	text := "meet at 2026-01-16T09:00:00Z then again at 2026-02-20T10:30:00Z"
+
+	results, err := p.ParseAll(text, base)
+	if err != nil {
+		t.Fatalf("ParseAll: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if !results[0].Time.Equal(time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("results[0].Time = %v", results[0].Time)
+	}
+	if !results[1].Time.Equal(time.Date(2026, 2, 20, 10, 30, 0, 0, time.UTC)) {
+		t.Fatalf("results[1].Time = %v", results[1].Time)
+	}
+}
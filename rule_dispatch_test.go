@@ -0,0 +1,38 @@
+package when
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runableapp/when-ng/rules/common"
+)
+
+func TestParseRFC2822TakesPrecedenceOverRegisteredLayouts(t *testing.T) {
+	p := New(nil)
+	p.Add(common.All...)
+
+	base := time.Now()
+
+	res, err := p.Parse("Mon, 02 Jan 2006 15:04:05 -0000", base)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if res == nil {
+		t.Fatal("Parse returned nil result")
+	}
+	if !res.ZoneUnknown {
+		t.Fatal("ZoneUnknown = false, want true for a \"-0000\" zone")
+	}
+
+	res, err = p.Parse("Mon, 02 Jan 2006 15:04:05 PST", base)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if res == nil {
+		t.Fatal("Parse returned nil result")
+	}
+	want := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.FixedZone("", -8*3600))
+	if !res.Time.Equal(want) {
+		t.Fatalf("Time = %v, want %v (PST, -08:00)", res.Time, want)
+	}
+}
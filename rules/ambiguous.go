@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// resolveWallClock builds the time.Time for a wall clock in loc, detecting
+// the two DST edge cases: a "spring forward" wall time that never occurred,
+// and a "fall back" wall time that occurred twice an hour apart. It returns
+// the resolved instant, the instant policy didn't pick (only set when
+// ambiguous is true), and whether the wall clock was ambiguous.
+func resolveWallClock(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location, policy AmbiguousTimePolicy) (t time.Time, alternative time.Time, ambiguous bool, err error) {
+	t = time.Date(year, month, day, hour, min, sec, nsec, loc)
+
+	// time.Date silently normalises a nonexistent wall clock (a DST gap)
+	// by shifting it forward by the gap's size - detect that by checking
+	// whether the resolved instant still has the wall clock we asked for.
+	if t.Year() != year || t.Month() != month || t.Day() != day || t.Hour() != hour || t.Minute() != min {
+		if policy == PolicyError {
+			return time.Time{}, time.Time{}, false, errors.New("time falls in a DST gap and has no valid instant")
+		}
+		return t, time.Time{}, false, nil
+	}
+
+	// A repeated wall clock has two valid instants an hour apart under
+	// different offsets; time.Date resolves to one of them. Check whether
+	// the offset an hour either side of it differs and reproduces the same
+	// wall clock to detect the overlap.
+	_, offset := t.Zone()
+	for _, probe := range []time.Time{t.Add(-time.Hour), t.Add(time.Hour)} {
+		_, otherOffset := probe.Zone()
+		if otherOffset == offset {
+			continue
+		}
+
+		candidate := time.Date(year, month, day, hour, min, sec, nsec, time.FixedZone("", otherOffset)).In(loc)
+		if candidate.Hour() != hour || candidate.Minute() != min {
+			continue
+		}
+
+		earliest, latest := t, candidate
+		if latest.Before(earliest) {
+			earliest, latest = latest, earliest
+		}
+
+		switch policy {
+		case PolicyError:
+			return time.Time{}, time.Time{}, false, errors.New("ambiguous local time: repeated DST hour")
+		case PolicyLatest:
+			return latest, earliest, true, nil
+		default:
+			return earliest, latest, true, nil
+		}
+	}
+
+	return t, time.Time{}, false, nil
+}
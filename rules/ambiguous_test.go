@@ -0,0 +1,57 @@
+package rules
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveWallClockFallBackDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	// 2024-11-03 01:30 America/New_York occurs twice: once at -04:00
+	// (before the fall-back) and once at -05:00 (after it).
+	earliest, latest, ambiguous, err := resolveWallClock(2024, time.November, 3, 1, 30, 0, 0, loc, PolicyEarliest)
+	if err != nil {
+		t.Fatalf("resolveWallClock: %v", err)
+	}
+	if !ambiguous {
+		t.Fatal("ambiguous = false, want true for the repeated DST hour")
+	}
+	if _, offset := earliest.Zone(); offset != -4*3600 {
+		t.Fatalf("earliest offset = %d, want -14400 (-04:00)", offset)
+	}
+	if _, offset := latest.Zone(); offset != -5*3600 {
+		t.Fatalf("latest (alternative) offset = %d, want -18000 (-05:00)", offset)
+	}
+
+	again, alt, ambiguous, err := resolveWallClock(2024, time.November, 3, 1, 30, 0, 0, loc, PolicyLatest)
+	if err != nil {
+		t.Fatalf("resolveWallClock: %v", err)
+	}
+	if !ambiguous {
+		t.Fatal("ambiguous = false, want true for the repeated DST hour")
+	}
+	if _, offset := again.Zone(); offset != -5*3600 {
+		t.Fatalf("PolicyLatest offset = %d, want -18000 (-05:00)", offset)
+	}
+	if _, offset := alt.Zone(); offset != -4*3600 {
+		t.Fatalf("PolicyLatest alternative offset = %d, want -14400 (-04:00)", offset)
+	}
+}
+
+func TestResolveWallClockSpringForwardGap(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+
+	// 2024-03-10 02:30 America/New_York never occurred: clocks jumped from
+	// 01:59:59 straight to 03:00:00.
+	_, _, _, err = resolveWallClock(2024, time.March, 10, 2, 30, 0, 0, loc, PolicyError)
+	if err == nil {
+		t.Fatal("resolveWallClock with PolicyError: got nil error, want one for a nonexistent wall clock")
+	}
+}
@@ -0,0 +1,10 @@
+package common
+
+import "github.com/runableapp/when-ng/rules"
+
+// All is the default, precedence-ordered set of locale-agnostic rules
+// shared by every language parser.
+var All = []rules.Rule{
+	ISODate(rules.Default),
+	RFC2822(),
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/runableapp/when-ng/rules"
@@ -19,15 +20,31 @@ ISO date formats:
 - 2026-01-16 04:00:00 AM
 - 2020-05-22T15:55-04:00
 - 2020-05-22T15:55:00Z
+- 2020-05-22t15:55:00.123456z (lowercase separator/zone, fractional seconds)
+
+The date/time separator accepts "T", "t", or one-or-more spaces, and the
+zone marker accepts "Z" in either case, independently of each other - the
+goal is that result.Time.Format(time.RFC3339Nano) fed back into Parse
+always round-trips.
 */
 
+// zoneBearingLayouts are the Go-parser fallback layouts whose matched text
+// pins down an explicit zone, as opposed to a naive wall clock that should
+// resolve in base's/DefaultLocation's zone instead.
+var zoneBearingLayouts = map[string]bool{
+	time.RFC3339:                true,
+	time.RFC3339Nano:            true,
+	"2006-01-02T15:04:05-07:00": true,
+	"2006-01-02T15:04:05Z":      true,
+}
+
 func ISODate(s rules.Strategy) rules.Rule {
 	return &rules.F{
 		// Match ISO date pattern: YYYY-MM-DD with optional time
 		// Match full string by including time in the capture group
 		RegExp: regexp.MustCompile("(?i)(?:\\W|^)" +
 			"((?:1|2)[0-9]{3}\\-[0-1][0-9]\\-[0-3][0-9]" + // Date part
-			"(?:[Tt\\s]+[0-9]{1,2}\\:[0-5][0-9](?:\\:[0-5][0-9])?(?:\\s*(?:A\\.|P\\.|A\\.M\\.|P\\.M\\.|AM?|PM?))?(?:[\\+\\-][0-9]{1,2}\\:[0-9]{2}|Z)?)?" + // Optional time
+			"(?:[Tt\\s]+[0-9]{1,2}\\:[0-5][0-9](?:\\:[0-5][0-9](?:\\.[0-9]{1,9})?)?(?:\\s*(?:A\\.|P\\.|A\\.M\\.|P\\.M\\.|AM?|PM?))?(?:[\\+\\-][0-9]{1,2}\\:[0-9]{2}|Z)?)?" + // Optional time
 			")(?:\\W|$)"),
 		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
 			if (c.Year != nil || c.Month != nil || c.Day != nil || c.Hour != nil || c.Minute != nil) && s != rules.Override {
@@ -36,32 +53,32 @@ func ISODate(s rules.Strategy) rules.Rule {
 
 			// Use m.Text which should contain the full matched string
 			matchedText := m.Text
-			
+
 			// Always try to extract full ISO pattern from context text (clustered match)
 			// This ensures we get the time part even if m.Text only has the date
-			fullPattern := regexp.MustCompile("((?:1|2)[0-9]{3}\\-[0-1][0-9]\\-[0-3][0-9](?:[Tt\\s]+[0-9]{1,2}\\:[0-5][0-9](?:\\:[0-5][0-9])?(?:\\s*(?:A\\.|P\\.|A\\.M\\.|P\\.M\\.|AM?|PM?))?(?:[\\+\\-][0-9]{1,2}\\:[0-9]{2}|Z)?)?)")
+			fullPattern := regexp.MustCompile("(?i)((?:1|2)[0-9]{3}\\-[0-1][0-9]\\-[0-3][0-9](?:[Tt\\s]+[0-9]{1,2}\\:[0-5][0-9](?:\\:[0-5][0-9](?:\\.[0-9]{1,9})?)?(?:\\s*(?:A\\.|P\\.|A\\.M\\.|P\\.M\\.|AM?|PM?))?(?:[\\+\\-][0-9]{1,2}\\:[0-9]{2}|Z)?)?)")
 			if fullMatches := fullPattern.FindStringSubmatch(c.Text); len(fullMatches) > 1 {
 				matchedText = fullMatches[1]
 			}
-			
+
 			// Trim whitespace
 			matchedText = regexp.MustCompile("^\\s+|\\s+$").ReplaceAllString(matchedText, "")
-			
+
 			// Try manual parsing first (more reliable for our specific formats)
 			var parsedTime time.Time
 			parsed := false
-			
+
 			// Manual parsing - extract components directly from the string
 			// This is more reliable than Go's parser for our specific formats
-			dateTimePattern := regexp.MustCompile("^((?:1|2)[0-9]{3})\\-([0-1][0-9])\\-([0-3][0-9])(?:[Tt\\s]+([0-9]{1,2})\\:([0-5][0-9])(?:\\:([0-5][0-9]))?(?:\\s*(AM|PM|A\\.M\\.|P\\.M\\.|A\\.|P\\.))?(?:([\\+\\-])([0-9]{2})\\:([0-9]{2})|Z)?)?$")
+			dateTimePattern := regexp.MustCompile("(?i)^((?:1|2)[0-9]{3})\\-([0-1][0-9])\\-([0-3][0-9])(?:[Tt\\s]+([0-9]{1,2})\\:([0-5][0-9])(?:\\:([0-5][0-9])(?:\\.([0-9]{1,9}))?)?(?:\\s*(AM|PM|A\\.M\\.|P\\.M\\.|A\\.|P\\.))?(?:([\\+\\-])([0-9]{2})\\:([0-9]{2})|Z)?)?$")
 			manualMatches := dateTimePattern.FindStringSubmatch(matchedText)
-			
+
 			// If manual parsing doesn't match, try without anchors (in case of extra chars)
 			if len(manualMatches) <= 3 {
-				dateTimePattern2 := regexp.MustCompile("((?:1|2)[0-9]{3})\\-([0-1][0-9])\\-([0-3][0-9])(?:[Tt\\s]+([0-9]{1,2})\\:([0-5][0-9])(?:\\:([0-5][0-9]))?(?:\\s*(AM|PM|A\\.M\\.|P\\.M\\.|A\\.|P\\.))?(?:([\\+\\-])([0-9]{2})\\:([0-9]{2})|Z)?)?")
+				dateTimePattern2 := regexp.MustCompile("(?i)((?:1|2)[0-9]{3})\\-([0-1][0-9])\\-([0-3][0-9])(?:[Tt\\s]+([0-9]{1,2})\\:([0-5][0-9])(?:\\:([0-5][0-9])(?:\\.([0-9]{1,9}))?)?(?:\\s*(AM|PM|A\\.M\\.|P\\.M\\.|A\\.|P\\.))?(?:([\\+\\-])([0-9]{2})\\:([0-9]{2})|Z)?)?")
 				manualMatches = dateTimePattern2.FindStringSubmatch(matchedText)
 			}
-			
+
 			if len(manualMatches) > 3 {
 				year, _ := strconv.Atoi(manualMatches[1])
 				month, _ := strconv.Atoi(manualMatches[2])
@@ -77,10 +94,21 @@ func ISODate(s rules.Strategy) rules.Rule {
 				if len(manualMatches) > 6 && manualMatches[6] != "" {
 					second, _ = strconv.Atoi(manualMatches[6])
 				}
-				
-				// Handle AM/PM
+
+				// Handle fractional seconds (1-9 digits, e.g. ".123456")
+				var nanosecond *int
 				if len(manualMatches) > 7 && manualMatches[7] != "" {
-					ampm := manualMatches[7]
+					frac := manualMatches[7]
+					for len(frac) < 9 {
+						frac += "0"
+					}
+					ns, _ := strconv.Atoi(frac[:9])
+					nanosecond = &ns
+				}
+
+				// Handle AM/PM
+				if len(manualMatches) > 8 && manualMatches[8] != "" {
+					ampm := manualMatches[8]
 					if len(ampm) > 0 && (ampm[0] == 'P' || ampm[0] == 'p') {
 						if hour < 12 {
 							hour += 12
@@ -89,7 +117,24 @@ func ISODate(s rules.Strategy) rules.Rule {
 						hour = 0
 					}
 				}
-				
+
+				// An explicit "+hh:mm"/"-hh:mm" offset (groups 9-11) or a
+				// trailing "Z"/"z" pins the result to that zone instead of
+				// leaving it naive to resolve in base's/DefaultLocation's.
+				var offset *int
+				if len(manualMatches) > 11 && manualMatches[9] != "" {
+					hh, _ := strconv.Atoi(manualMatches[10])
+					mm, _ := strconv.Atoi(manualMatches[11])
+					off := hh*3600 + mm*60
+					if manualMatches[9] == "-" {
+						off = -off
+					}
+					offset = &off
+				} else if strings.HasSuffix(matchedText, "Z") || strings.HasSuffix(matchedText, "z") {
+					off := 0
+					offset = &off
+				}
+
 				// Set values directly from parsed components
 				c.Year = &year
 				c.Month = &month
@@ -97,7 +142,9 @@ func ISODate(s rules.Strategy) rules.Rule {
 				c.Hour = &hour
 				c.Minute = &minute
 				c.Second = &second
-				
+				c.Nanosecond = nanosecond
+				c.Offset = offset
+
 				return true, nil
 			} else {
 				// Fallback to Go's time parser
@@ -119,17 +166,24 @@ func ISODate(s rules.Strategy) rules.Rule {
 				}
 				
 				var err error
+				var matchedLayout string
 				for _, layout := range layouts {
 					parsedTime, err = time.Parse(layout, matchedText)
 					if err == nil {
 						parsed = true
+						matchedLayout = layout
 						break
 					}
 				}
-				
+
 				if !parsed {
 					return false, nil
 				}
+
+				if zoneBearingLayouts[matchedLayout] {
+					_, off := parsedTime.Zone()
+					c.Offset = &off
+				}
 			}
 
 			// Extract components from parsed time (fallback to Go's parser)
@@ -146,6 +200,9 @@ func ISODate(s rules.Strategy) rules.Rule {
 			c.Hour = &hour
 			c.Minute = &minute
 			c.Second = &second
+			if ns := parsedTime.Nanosecond(); ns != 0 {
+				c.Nanosecond = &ns
+			}
 
 			return true, nil
 		},
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runableapp/when-ng/rules"
+)
+
+func TestISODateExplicitOffsetIsPlumbedThrough(t *testing.T) {
+	text := "2020-05-22T15:55:00-04:00"
+
+	ctx := &rules.Context{Text: text}
+	m := ISODate(rules.Default).Find(text)
+	if m == nil {
+		t.Fatalf("ISODate did not match %q", text)
+	}
+	ok, err := m.Apply(ctx, &rules.Options{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if !ok {
+		t.Fatalf("Apply did not accept %q", text)
+	}
+	if ctx.Offset == nil || *ctx.Offset != -4*3600 {
+		t.Fatalf("Offset = %v, want -14400 (-04:00)", ctx.Offset)
+	}
+
+	got, err := ctx.Time(time.Time{}, nil)
+	if err != nil {
+		t.Fatalf("Time: %v", err)
+	}
+	want := time.Date(2020, time.May, 22, 15, 55, 0, 0, time.FixedZone("", -4*3600))
+	if !got.Equal(want) {
+		t.Fatalf("Time = %v, want %v", got, want)
+	}
+}
+
+func TestISODateZIsUTCOffset(t *testing.T) {
+	text := "2020-05-22T15:55:00Z"
+
+	ctx := &rules.Context{Text: text}
+	m := ISODate(rules.Default).Find(text)
+	if m == nil {
+		t.Fatalf("ISODate did not match %q", text)
+	}
+	ok, err := m.Apply(ctx, &rules.Options{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if !ok {
+		t.Fatalf("Apply did not accept %q", text)
+	}
+	if ctx.Offset == nil || *ctx.Offset != 0 {
+		t.Fatalf("Offset = %v, want 0 (Z)", ctx.Offset)
+	}
+}
+
+func TestISODateNaiveLeavesOffsetUnset(t *testing.T) {
+	text := "2020-05-22T15:55:00"
+
+	ctx := &rules.Context{Text: text}
+	m := ISODate(rules.Default).Find(text)
+	if m == nil {
+		t.Fatalf("ISODate did not match %q", text)
+	}
+	ok, err := m.Apply(ctx, &rules.Options{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if !ok {
+		t.Fatalf("Apply did not accept %q", text)
+	}
+	if ctx.Offset != nil {
+		t.Fatalf("Offset = %v, want nil for a naive timestamp", ctx.Offset)
+	}
+}
@@ -0,0 +1,40 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runableapp/when-ng/rules"
+)
+
+func TestISODateRoundTrip(t *testing.T) {
+	cases := []string{
+		"2020-05-22T15:55:30Z",
+		"2020-05-22t15:55:30.123456z",
+	}
+
+	for _, text := range cases {
+		ctx := &rules.Context{Text: text}
+		m := ISODate(rules.Default).Find(text)
+		if m == nil {
+			t.Fatalf("ISODate did not match %q", text)
+		}
+		ok, err := m.Apply(ctx, &rules.Options{}, time.Time{})
+		if err != nil {
+			t.Fatalf("Apply(%q): %v", text, err)
+		}
+		if !ok {
+			t.Fatalf("Apply(%q) did not accept", text)
+		}
+
+		if ctx.Day == nil || *ctx.Day != 22 {
+			t.Fatalf("Day = %v, want 22 for %q", ctx.Day, text)
+		}
+		if ctx.Minute == nil || *ctx.Minute != 55 {
+			t.Fatalf("Minute = %v, want 55 for %q", ctx.Minute, text)
+		}
+		if ctx.Second == nil || *ctx.Second != 30 {
+			t.Fatalf("Second = %v, want 30 for %q", ctx.Second, text)
+		}
+	}
+}
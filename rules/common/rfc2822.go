@@ -0,0 +1,115 @@
+package common
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/runableapp/when-ng/rules"
+)
+
+// rfc2822Months maps the three-letter month abbreviations used by RFC 2822
+// (and its RFC 1123 successor) to their numeric value.
+var rfc2822Months = map[string]int{
+	"Jan": 1, "Feb": 2, "Mar": 3, "Apr": 4, "May": 5, "Jun": 6,
+	"Jul": 7, "Aug": 8, "Sep": 9, "Oct": 10, "Nov": 11, "Dec": 12,
+}
+
+// rfc2822NamedZones are the named zones RFC 2822 §4.3 lists alongside the
+// obsolete military single-letter zones, with their offsets in seconds.
+var rfc2822NamedZones = map[string]int{
+	"UT": 0, "GMT": 0,
+	"EST": -5 * 3600, "EDT": -4 * 3600,
+	"CST": -6 * 3600, "CDT": -5 * 3600,
+	"MST": -7 * 3600, "MDT": -6 * 3600,
+	"PST": -8 * 3600, "PDT": -7 * 3600,
+}
+
+var rfc2822Pattern = regexp.MustCompile(`(?i)(?:\W|^)` +
+	`(?:(?:Mon|Tue|Wed|Thu|Fri|Sat|Sun)\s*,\s*)?` +
+	`([0-3]?[0-9])\s+` +
+	`(Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)\s+` +
+	`([0-9]{2,4})\s+` +
+	`([0-2][0-9]):([0-5][0-9])(?::([0-5][0-9]))?\s+` +
+	`([+-][0-9]{4}|UT|GMT|EST|EDT|CST|CDT|MST|MDT|PST|PDT|[A-IK-Za-ik-z])` +
+	`(?:\W|$)`)
+
+// RFC2822 recognises RFC 2822 / RFC 1123 style dates, e.g.
+// "Tue, 01 Jul 2003 10:52:37 +0200" or "Mon, 02 Jan 2006 15:04:05 -0000".
+//
+// RFC 2822 senders emit a "-0000" offset to mean "local time, zone unknown",
+// as distinct from an asserted "+0000". Both resolve to UTC, but the former
+// also sets Context.ZoneUnknown so downstream code can tell them apart. The
+// obsolete military single-letter zones (RFC 2822 §4.3) are unreliable by
+// spec and are treated the same way as "-0000".
+func RFC2822() rules.Rule {
+	return &rules.F{
+		RegExp: rfc2822Pattern,
+		Applier: func(m *rules.Match, c *rules.Context, o *rules.Options, ref time.Time) (bool, error) {
+			if c.Year != nil || c.Month != nil || c.Day != nil {
+				return false, nil
+			}
+
+			groups := rfc2822Pattern.FindStringSubmatch(m.Text)
+			if groups == nil {
+				return false, nil
+			}
+
+			day, _ := strconv.Atoi(groups[1])
+			month := rfc2822Months[titleCase(groups[2])]
+			year, _ := strconv.Atoi(groups[3])
+			if year < 100 {
+				if year < 50 {
+					year += 2000
+				} else {
+					year += 1900
+				}
+			}
+			hour, _ := strconv.Atoi(groups[4])
+			minute, _ := strconv.Atoi(groups[5])
+			second := 0
+			if groups[6] != "" {
+				second, _ = strconv.Atoi(groups[6])
+			}
+
+			offset, zoneUnknown := rfc2822Zone(groups[7])
+
+			c.Year, c.Month, c.Day = &year, &month, &day
+			c.Hour, c.Minute, c.Second = &hour, &minute, &second
+			c.Offset = &offset
+			c.ZoneUnknown = zoneUnknown
+
+			return true, nil
+		},
+	}
+}
+
+// rfc2822Zone resolves an RFC 2822 zone token to a UTC offset in seconds,
+// and whether that offset is actually just an unknown-zone placeholder.
+func rfc2822Zone(zone string) (offsetSeconds int, unknown bool) {
+	if zone == "-0000" {
+		return 0, true
+	}
+	if len(zone) == 5 && (zone[0] == '+' || zone[0] == '-') {
+		hh, _ := strconv.Atoi(zone[1:3])
+		mm, _ := strconv.Atoi(zone[3:5])
+		offset := hh*3600 + mm*60
+		if zone[0] == '-' {
+			offset = -offset
+		}
+		return offset, false
+	}
+	if off, ok := rfc2822NamedZones[strings.ToUpper(zone)]; ok {
+		return off, false
+	}
+	// A single obsolete military letter (A-Z except J, case-insensitive).
+	return 0, true
+}
+
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/runableapp/when-ng/rules"
+)
+
+func applyRFC2822(t *testing.T, text string) *rules.Context {
+	t.Helper()
+
+	rule := RFC2822()
+	m := rule.Find(text)
+	if m == nil {
+		t.Fatalf("RFC2822 did not match %q", text)
+	}
+
+	ctx := &rules.Context{Text: m.Text}
+	ok, err := m.Apply(ctx, &rules.Options{}, time.Time{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if !ok {
+		t.Fatalf("Apply did not accept %q", text)
+	}
+	return ctx
+}
+
+func TestRFC2822UnknownZone(t *testing.T) {
+	ctx := applyRFC2822(t, "Mon, 02 Jan 2006 15:04:05 -0000")
+
+	if !ctx.ZoneUnknown {
+		t.Fatal("ZoneUnknown = false, want true for a \"-0000\" zone")
+	}
+	if ctx.Offset == nil || *ctx.Offset != 0 {
+		t.Fatalf("Offset = %v, want 0", ctx.Offset)
+	}
+}
+
+func TestRFC2822NamedZone(t *testing.T) {
+	ctx := applyRFC2822(t, "Mon, 02 Jan 2006 15:04:05 PST")
+
+	if ctx.ZoneUnknown {
+		t.Fatal("ZoneUnknown = true, want false for a named zone")
+	}
+	if ctx.Offset == nil || *ctx.Offset != -8*3600 {
+		t.Fatalf("Offset = %v, want -28800 (PST)", ctx.Offset)
+	}
+
+	got, err := ctx.Time(time.Time{}, nil)
+	if err != nil {
+		t.Fatalf("Time: %v", err)
+	}
+	want := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.FixedZone("", -8*3600))
+	if !got.Equal(want) {
+		t.Fatalf("Time = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,95 @@
+package rules
+
+import "time"
+
+// Context accumulates the date/time components discovered by the rules
+// applied to a single matched cluster of text.
+type Context struct {
+	// Text is the text of the matched cluster.
+	Text string
+
+	Year       *int
+	Month      *int
+	Day        *int
+	Hour       *int
+	Minute     *int
+	Second     *int
+	Nanosecond *int
+
+	// Offset, when set by a rule, pins the resolved time to an explicit
+	// UTC offset in seconds instead of the base time's location.
+	Offset *int
+	// ZoneUnknown marks that a rule recognised a zone marker whose offset
+	// isn't actually known (e.g. RFC 2822's "-0000" convention) even
+	// though Offset was set to resolve it.
+	ZoneUnknown bool
+
+	// Ambiguous and Alternative are populated by Time when a naive match
+	// falls in a repeated "fall back" DST hour: Ambiguous reports whether
+	// that happened, and Alternative holds the instant
+	// Options.AmbiguousTimePolicy didn't pick. A wall clock in a "spring
+	// forward" gap leaves Ambiguous false.
+	Ambiguous   bool
+	Alternative time.Time
+}
+
+// Time resolves the accumulated components into a concrete time.Time,
+// falling back to the matching field of base for anything left unset. The
+// exception is Nanosecond: once Second is set, an unset Nanosecond means
+// "no fraction" and resolves to 0 rather than base's, so base's wall-clock
+// jitter can't leak into an otherwise fully-specified timestamp. o may be
+// nil, in which case base's location and PolicyEarliest are used.
+func (c *Context) Time(base time.Time, o *Options) (time.Time, error) {
+	year, month, day := base.Date()
+	hour, min, sec := base.Clock()
+	nsec := base.Nanosecond()
+
+	if c.Year != nil {
+		year = *c.Year
+	}
+	if c.Month != nil {
+		month = time.Month(*c.Month)
+	}
+	if c.Day != nil {
+		day = *c.Day
+	}
+	if c.Hour != nil {
+		hour = *c.Hour
+	}
+	if c.Minute != nil {
+		min = *c.Minute
+	}
+	if c.Second != nil {
+		sec = *c.Second
+		// A rule that pins Second down to the ones place but finds no
+		// fraction means the timestamp has none - fall back to zero
+		// instead of leaking base's wall-clock jitter into the result.
+		nsec = 0
+	}
+	if c.Nanosecond != nil {
+		nsec = *c.Nanosecond
+	}
+
+	if c.Offset != nil {
+		loc := time.FixedZone("", *c.Offset)
+		return time.Date(year, month, day, hour, min, sec, nsec, loc), nil
+	}
+
+	loc := base.Location()
+	policy := PolicyEarliest
+	if o != nil {
+		if o.DefaultLocation != nil {
+			loc = o.DefaultLocation
+		}
+		policy = o.AmbiguousTimePolicy
+	}
+
+	t, alt, ambiguous, err := resolveWallClock(year, month, day, hour, min, sec, nsec, loc, policy)
+	if err != nil {
+		return time.Time{}, err
+	}
+	c.Ambiguous = ambiguous
+	c.Alternative = alt
+
+	return t, nil
+}
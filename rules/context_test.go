@@ -0,0 +1,55 @@
+package rules
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContextTimeZeroesNanosecondWhenSecondIsSetWithoutFraction(t *testing.T) {
+	year, month, day := 2026, 1, 16
+	hour, min, sec := 9, 15, 42
+
+	c := &Context{
+		Year:   &year,
+		Month:  &month,
+		Day:    &day,
+		Hour:   &hour,
+		Minute: &min,
+		Second: &sec,
+	}
+
+	// base's wall-clock jitter (a nonzero nanosecond) must not leak into a
+	// fully-specified timestamp that has no fractional part of its own.
+	base := time.Date(2000, 1, 1, 0, 0, 0, 123456789, time.UTC)
+
+	got, err := c.Time(base, nil)
+	if err != nil {
+		t.Fatalf("Time: %v", err)
+	}
+	if got.Nanosecond() != 0 {
+		t.Fatalf("Nanosecond = %d, want 0", got.Nanosecond())
+	}
+}
+
+func TestContextTimeKeepsExplicitNanosecond(t *testing.T) {
+	year, month, day := 2026, 1, 16
+	hour, min, sec, nsec := 9, 15, 42, 123000000
+
+	c := &Context{
+		Year:       &year,
+		Month:      &month,
+		Day:        &day,
+		Hour:       &hour,
+		Minute:     &min,
+		Second:     &sec,
+		Nanosecond: &nsec,
+	}
+
+	got, err := c.Time(time.Now(), nil)
+	if err != nil {
+		t.Fatalf("Time: %v", err)
+	}
+	if got.Nanosecond() != nsec {
+		t.Fatalf("Nanosecond = %d, want %d", got.Nanosecond(), nsec)
+	}
+}
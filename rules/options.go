@@ -0,0 +1,57 @@
+package rules
+
+import "time"
+
+// Strategy controls how an Applier behaves when the shared Context already
+// holds a value for the component it is about to set.
+type Strategy int
+
+const (
+	// Default leaves an already-set component alone.
+	Default Strategy = iota
+	// Override replaces whatever is already present in the Context.
+	Override
+	// Skip causes the rule to be ignored once another rule has already
+	// populated the Context, rather than contending for the same fields.
+	Skip
+)
+
+// AmbiguousTimePolicy selects how a naive wall-clock time that falls during
+// a DST transition is resolved: a repeated "fall back" hour has two valid
+// instants, and a skipped "spring forward" hour has none.
+type AmbiguousTimePolicy int
+
+const (
+	// PolicyEarliest picks the earlier of the two valid instants for a
+	// repeated hour. This is the default.
+	PolicyEarliest AmbiguousTimePolicy = iota
+	// PolicyLatest picks the later of the two valid instants.
+	PolicyLatest
+	// PolicyError rejects ambiguous or nonexistent wall-clock times with
+	// an error instead of silently picking one.
+	PolicyError
+)
+
+// Options configures how a Parser finds and resolves matches.
+type Options struct {
+	// Distance is the maximum number of characters allowed between two
+	// matches for them to be considered part of the same cluster.
+	Distance int
+	// MatchByOrder, when true, applies matches in the order their rules
+	// were registered rather than the order they were found in the text.
+	MatchByOrder bool
+	// PreferDayFirst resolves the month/day ambiguity in numeric dates
+	// (e.g. "3/4/2026") towards day-first (DMY) when true, and towards
+	// month-first (MDY, the US convention) when false.
+	PreferDayFirst bool
+	// MaxResults caps the number of clusters Parser.ParseAll will resolve,
+	// to bound work on pathological inputs. Zero means unlimited.
+	MaxResults int
+	// DefaultLocation, when set, is the zone a naive (zone-less) match
+	// resolves in, instead of the base time's location.
+	DefaultLocation *time.Location
+	// AmbiguousTimePolicy controls how DST "spring forward"/"fall back"
+	// ambiguities are resolved for naive matches. Zero value is
+	// PolicyEarliest.
+	AmbiguousTimePolicy AmbiguousTimePolicy
+}
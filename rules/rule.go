@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"regexp"
+	"time"
+)
+
+// Rule finds the next candidate match for a date/time expression in text.
+type Rule interface {
+	Find(text string) *Match
+}
+
+// RuleAll is implemented by a Rule that can report every non-overlapping
+// match in text instead of just the first. findMatches consults it when a
+// registered Rule implements it, so ParseAll can resolve every cluster a
+// single rule matches rather than only its first.
+type RuleAll interface {
+	FindAll(text string) []*Match
+}
+
+// Match is a single match found by a Rule, paired with the applier that
+// turns it into Context fields once its cluster is resolved.
+type Match struct {
+	Left, Right int
+	Text        string
+	Captures    []string
+	Order       float64
+
+	applier func(m *Match, c *Context, o *Options, ref time.Time) (bool, error)
+}
+
+// Apply runs the Match's applier against the shared Context for its cluster.
+func (m *Match) Apply(c *Context, o *Options, ref time.Time) (bool, error) {
+	return m.applier(m, c, o, ref)
+}
+
+// F is a regexp-backed Rule. Its Applier is invoked once the Match it
+// produced is folded into a cluster's Context.
+type F struct {
+	RegExp  *regexp.Regexp
+	Applier func(m *Match, c *Context, o *Options, ref time.Time) (bool, error)
+}
+
+// Find returns the first match of RegExp in text, or nil if there is none.
+func (f *F) Find(text string) *Match {
+	all := f.FindAll(text)
+	if len(all) == 0 {
+		return nil
+	}
+	return all[0]
+}
+
+// FindAll returns every non-overlapping match of RegExp in text, scanning
+// left to right and resuming after the end of each match found.
+func (f *F) FindAll(text string) []*Match {
+	var matches []*Match
+
+	offset := 0
+	for offset <= len(text) {
+		loc := f.RegExp.FindStringSubmatchIndex(text[offset:])
+		if loc == nil {
+			break
+		}
+
+		left, right := offset+loc[0], offset+loc[1]
+		captures := make([]string, 0, len(loc)/2)
+		for i := 2; i < len(loc); i += 2 {
+			if loc[i] < 0 {
+				captures = append(captures, "")
+				continue
+			}
+			captures = append(captures, text[offset+loc[i]:offset+loc[i+1]])
+		}
+
+		matches = append(matches, &Match{
+			Left:     left,
+			Right:    right,
+			Text:     text[left:right],
+			Captures: captures,
+			applier:  f.Applier,
+		})
+
+		if right == left {
+			offset = right + 1
+		} else {
+			offset = right
+		}
+	}
+
+	return matches
+}
+
+// MatchByIndex sorts matches by their position in the source text.
+type MatchByIndex []*Match
+
+func (m MatchByIndex) Len() int           { return len(m) }
+func (m MatchByIndex) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m MatchByIndex) Less(i, j int) bool { return m[i].Left < m[j].Left }
+
+// MatchByOrder sorts matches by the order their rule was registered in.
+type MatchByOrder []*Match
+
+func (m MatchByOrder) Len() int           { return len(m) }
+func (m MatchByOrder) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m MatchByOrder) Less(i, j int) bool { return m[i].Order < m[j].Order }
@@ -0,0 +1,192 @@
+package when
+
+import "strings"
+
+// scanLayout performs a single left-to-right scan over text looking for a
+// date/time-shaped run. It classifies runs of digits and separators as it
+// goes and builds a time.Parse-compatible layout on the fly, instead of
+// trying a hardcoded list of layouts against the whole string.
+//
+// A 4-digit run forces a year wherever it appears. A 1-2 digit run before
+// the first date separator is ambiguous between month and day and is
+// resolved by preferDayFirst. "T" or a run of spaces between the date and a
+// "HH:MM" shaped run toggles into the time-of-day part. A trailing "Z",
+// "+07:00"/"-07:00", or a bare zone name ("GMT", "UTC" or "MST") is
+// consumed as a zone chunk - once the time-of-day part has started, a "-"
+// is treated as the sign of that trailing offset rather than another
+// component separator, so it's left for scanZone instead of being absorbed
+// as a bogus extra time component.
+//
+// It returns ("", "") if text does not contain anything date-shaped.
+func scanLayout(text string, preferDayFirst bool) (layout string, matched string) {
+	type run struct {
+		digits string
+		sep    byte
+	}
+
+	isDigit := func(b byte) bool { return b >= '0' && b <= '9' }
+
+	start := -1
+	for i := 0; i < len(text); i++ {
+		if isDigit(text[i]) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return "", ""
+	}
+
+	var runs []run
+	pos := start
+	lastEnd := start
+	seenTimeSep := false
+	for pos < len(text) {
+		j := pos
+		for j < len(text) && isDigit(text[j]) {
+			j++
+		}
+		if j == pos {
+			break
+		}
+		digits := text[pos:j]
+		pos = j
+		lastEnd = j
+
+		var sep byte
+		switch {
+		case pos < len(text) && (text[pos] == 'T' || text[pos] == 't'):
+			sep = 'T'
+			pos++
+			seenTimeSep = true
+		case pos < len(text) && text[pos] == ' ':
+			sep = ' '
+			for pos < len(text) && text[pos] == ' ' {
+				pos++
+			}
+			seenTimeSep = true
+		// Once we're past the date/time separator, a leading "-" is the
+		// sign of a trailing "-07:00" offset, not another component
+		// separator - stop here and let scanZone claim it instead of
+		// absorbing the offset's digits as bogus extra time components.
+		case pos < len(text) && text[pos] == '-' && seenTimeSep:
+		case pos < len(text) && strings.IndexByte("-/.:", text[pos]) >= 0:
+			sep = text[pos]
+			pos++
+		}
+
+		runs = append(runs, run{digits: digits, sep: sep})
+		if sep == 0 {
+			break
+		}
+	}
+	if len(runs) == 0 {
+		return "", ""
+	}
+
+	zone := scanZone(text[pos:])
+	// A separator after the last run is kept only when a zone chunk follows
+	// it; otherwise it's left out of both the match and the layout.
+	trailingSep := runs[len(runs)-1].sep != 0
+	if trailingSep && zone.matched == "" {
+		pos = lastEnd
+	}
+	pos += len(zone.matched)
+
+	// A date that starts with a 4-digit year is year-month-day whatever
+	// preferDayFirst says: the month/day ambiguity only applies to a date
+	// that leads with a 1-2 digit run.
+	yearFirst := len(runs[0].digits) == 4
+
+	// dateComponents and timeComponents count only the ambiguous (non-year)
+	// runs classified *so far*, and inTime is only raised once a run's own
+	// trailing T/space separator has been seen - so run i is always
+	// classified from state built by runs before it, never by its own
+	// trailing separator.
+	var b strings.Builder
+	dateComponents, timeComponents := 0, 0
+	inTime := false
+
+	for i, r := range runs {
+		switch {
+		case len(r.digits) == 4:
+			b.WriteString("2006")
+		case inTime:
+			b.WriteString(timeComponentLayout(timeComponents, len(r.digits)))
+			timeComponents++
+		case dateComponents == 0:
+			b.WriteString(monthOrDayLayout(len(r.digits), preferDayFirst && !yearFirst, true))
+			dateComponents++
+		case dateComponents == 1:
+			b.WriteString(monthOrDayLayout(len(r.digits), preferDayFirst && !yearFirst, false))
+			dateComponents++
+		default:
+			b.WriteString(map[bool]string{true: "02", false: "2"}[len(r.digits) == 2])
+			dateComponents++
+		}
+
+		if i == len(runs)-1 && (!trailingSep || zone.matched == "") {
+			break
+		}
+		switch r.sep {
+		case 'T':
+			b.WriteByte('T')
+			inTime = true
+		case ' ':
+			b.WriteByte(' ')
+			inTime = true
+		default:
+			b.WriteByte(r.sep)
+		}
+	}
+
+	b.WriteString(zone.layout)
+	return b.String(), text[start:pos]
+}
+
+// timeComponentLayout picks the reference layout token for the ordinal-th
+// (0 = hour, 1 = minute, 2 = second) run of the time-of-day part.
+func timeComponentLayout(ordinal, width int) string {
+	wide := width == 2
+	switch ordinal {
+	case 0:
+		return map[bool]string{true: "15", false: "3"}[wide]
+	case 1:
+		return map[bool]string{true: "04", false: "4"}[wide]
+	default:
+		return map[bool]string{true: "05", false: "5"}[wide]
+	}
+}
+
+// monthOrDayLayout picks the "01"/"1" (month) or "02"/"2" (day) reference
+// layout token for the first or second numeric date component.
+func monthOrDayLayout(width int, preferDayFirst, firstComponent bool) string {
+	isMonth := firstComponent != preferDayFirst
+	if isMonth {
+		return map[bool]string{true: "01", false: "1"}[width == 2]
+	}
+	return map[bool]string{true: "02", false: "2"}[width == 2]
+}
+
+type zoneMatch struct {
+	layout  string
+	matched string
+}
+
+// scanZone recognises the trailing zone chunk of a date/time, if any.
+func scanZone(rest string) zoneMatch {
+	switch {
+	case strings.HasPrefix(rest, "Z"):
+		// "Z07:00" (not a literal "Z") so time.Parse reads "Z" as UTC.
+		return zoneMatch{layout: "Z07:00", matched: "Z"}
+	case len(rest) >= 6 && (rest[0] == '+' || rest[0] == '-') && rest[3] == ':':
+		return zoneMatch{layout: "Z07:00", matched: rest[:6]}
+	default:
+		for _, name := range []string{"GMT", "UTC", "MST"} {
+			if strings.HasPrefix(rest, name) {
+				return zoneMatch{layout: "MST", matched: name}
+			}
+		}
+	}
+	return zoneMatch{}
+}
@@ -0,0 +1,59 @@
+package when
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScanLayoutSpaceSeparatedDateTime(t *testing.T) {
+	text := "2026-01-16 09:15:42"
+
+	layout, matched := scanLayout(text, false)
+	if matched != text {
+		t.Fatalf("matched = %q, want %q", matched, text)
+	}
+
+	const want = "2006-01-02 15:04:05"
+	if layout != want {
+		t.Fatalf("layout = %q, want %q", layout, want)
+	}
+
+	parsed, err := time.Parse(layout, text)
+	if err != nil {
+		t.Fatalf("time.Parse(%q, %q): %v", layout, text, err)
+	}
+	if got := parsed.Format("2006-01-02 15:04:05"); got != text {
+		t.Fatalf("round-trip = %q, want %q", got, text)
+	}
+	if parsed.Minute() != 15 {
+		t.Fatalf("minute = %d, want 15", parsed.Minute())
+	}
+	if parsed.Second() != 42 {
+		t.Fatalf("second = %d, want 42", parsed.Second())
+	}
+}
+
+func TestScanLayoutNegativeOffset(t *testing.T) {
+	text := "2020-05-22T15:55:30-07:15"
+
+	layout, matched := scanLayout(text, false)
+	if matched != text {
+		t.Fatalf("matched = %q, want %q", matched, text)
+	}
+
+	const want = "2006-01-02T15:04:05Z07:00"
+	if layout != want {
+		t.Fatalf("layout = %q, want %q", layout, want)
+	}
+
+	parsed, err := time.Parse(layout, text)
+	if err != nil {
+		t.Fatalf("time.Parse(%q, %q): %v", layout, text, err)
+	}
+	if _, offset := parsed.Zone(); offset != -7*3600-15*60 {
+		t.Fatalf("offset = %d, want -26100 (-07:15)", offset)
+	}
+	if parsed.Second() != 30 {
+		t.Fatalf("second = %d, want 30 (not absorbed by the offset)", parsed.Second())
+	}
+}
@@ -2,6 +2,7 @@ package when
 
 import (
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -19,6 +20,9 @@ type Parser struct {
 	options    *rules.Options
 	rules      []rules.Rule
 	middleware []func(string) (string, error)
+
+	layouts        []string
+	removedLayouts map[string]bool
 }
 
 // Result is a struct which contains parsing meta-info
@@ -31,6 +35,18 @@ type Result struct {
 	Source string
 	// Time is an output time
 	Time time.Time
+	// ZoneUnknown is true when a rule recognised a zone marker whose
+	// offset isn't actually known (e.g. RFC 2822's "-0000" convention),
+	// as distinct from an asserted "+0000"/UTC.
+	ZoneUnknown bool
+	// Zone is the location Time was resolved in.
+	Zone *time.Location
+	// Ambiguous is true when Time fell during a DST transition and
+	// Options.AmbiguousTimePolicy had to pick between two valid instants.
+	Ambiguous bool
+	// Alternative holds the instant AmbiguousTimePolicy didn't pick,
+	// valid only when Ambiguous is true.
+	Alternative time.Time
 }
 
 // Parse returns Result and error if any. If have not matches it returns nil, nil.
@@ -54,59 +70,147 @@ func (p *Parser) Parse(text string, base time.Time) (*Result, error) {
 		}
 	}
 
-	// First, try Go's built-in date/time parsing for standard formats
-	// This handles ISO 8601, RFC3339, and other standard formats
-	if parsedTime, matchedText := tryStandardTimeFormats(text, base); parsedTime != nil {
+	// First, try the scanner's numeric fast path (ISO 8601 and RFC3339
+	// shapes that lead with a "YYYY-" year): it's unambiguous and can't be
+	// confused with anything a rule handles.
+	if scanned, matchedText := tryScannedTimeFormat(p, text, res.Time); scanned != nil {
+		scanned.Source = text
+		scanned.Text = matchedText
+		scanned.Index = 0
+		return scanned, nil
+	}
+
+	matches := p.findMatches(text)
+	if len(matches) > 0 {
+		sort.Sort(rules.MatchByIndex(matches))
+
+		cluster, left, right, _ := nextCluster(matches, p.options.Distance)
+		result, err := p.resolveCluster(cluster, text[left:right], left, res.Time)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			result.Source = text
+			return result, nil
+		}
+	}
+
+	// Only now fall back to p's registered layouts (see RegisterLayout and
+	// Parser.AddLayout): they're tried last so a weekday/month-name shape a
+	// rule already understands more precisely (e.g. RFC2822, with its
+	// named-zone table and "-0000" handling) isn't preempted by a bare
+	// time.Parse that doesn't know about either.
+	if parsedTime, matchedText := tryRegisteredLayouts(p, text, res.Time); parsedTime != nil {
+		res.Zone = parsedTime.Location()
 		res.Time = *parsedTime
 		res.Text = matchedText
 		res.Index = 0
 		return &res, nil
 	}
 
-	// find all matches
+	return nil, nil
+}
+
+// ParseAll returns a Result for every independent date/time cluster in
+// text, instead of just the first one. It keeps scanning after each
+// resolved cluster, advancing past its end. Options.MaxResults caps the
+// number of clusters resolved, to bound work on pathological inputs; zero
+// means unlimited.
+func (p *Parser) ParseAll(text string, base time.Time) ([]*Result, error) {
+	if p.options == nil {
+		p.options = defaultOptions
+	}
+
+	var err error
+	for _, b := range p.middleware {
+		text, err = b(text)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matches := p.findMatches(text)
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	sort.Sort(rules.MatchByIndex(matches))
+
+	var results []*Result
+	for len(matches) > 0 {
+		if p.options.MaxResults > 0 && len(results) >= p.options.MaxResults {
+			break
+		}
+
+		var cluster []*rules.Match
+		var left, right int
+		cluster, left, right, matches = nextCluster(matches, p.options.Distance)
+
+		result, err := p.resolveCluster(cluster, text[left:right], left, base)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			result.Source = text
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// findMatches runs every registered rule against text and collects the
+// matches it finds, tagging each with its registration order. A rule that
+// implements rules.RuleAll contributes every match it finds instead of just
+// the first, so ParseAll can resolve every cluster it matches.
+func (p *Parser) findMatches(text string) []*rules.Match {
 	matches := make([]*rules.Match, 0)
 	c := float64(0)
 	for _, rule := range p.rules {
-		r := rule.Find(text)
-		if r != nil {
+		var found []*rules.Match
+		if all, ok := rule.(rules.RuleAll); ok {
+			found = all.FindAll(text)
+		} else if r := rule.Find(text); r != nil {
+			found = []*rules.Match{r}
+		}
+
+		for _, r := range found {
 			r.Order = c
 			c++
 			matches = append(matches, r)
 		}
 	}
+	return matches
+}
 
-	// not found
-	if len(matches) == 0 {
-		return nil, nil
-	}
-
-	// find a cluster
-	sort.Sort(rules.MatchByIndex(matches))
-
-	// get borders of the matches
-	end := matches[0].Right
-	res.Index = matches[0].Left
+// nextCluster consumes the run of matches at the front of matches (already
+// sorted by index) that are no more than distance characters apart, and
+// returns its bounds along with the matches left to resume scanning from.
+func nextCluster(matches []*rules.Match, distance int) (cluster []*rules.Match, left, right int, rest []*rules.Match) {
+	left = matches[0].Left
+	right = matches[0].Right
 
 	for i, m := range matches {
-		if m.Left <= end+p.options.Distance {
-			end = m.Right
+		if m.Left <= right+distance {
+			right = m.Right
 		} else {
-			matches = matches[:i]
-			break
+			return matches[:i], left, right, matches[i:]
 		}
 	}
 
-	res.Text = text[res.Index:end]
+	return matches, left, right, nil
+}
 
-	// apply rules
+// resolveCluster applies cluster's matches to a fresh Context and returns
+// the Result it resolves to, or nil if none of them applied.
+func (p *Parser) resolveCluster(cluster []*rules.Match, clusterText string, left int, base time.Time) (*Result, error) {
 	if p.options.MatchByOrder {
-		sort.Sort(rules.MatchByOrder(matches))
+		sort.Sort(rules.MatchByOrder(cluster))
 	}
 
-	ctx := &rules.Context{Text: res.Text}
+	ctx := &rules.Context{Text: clusterText}
 	applied := false
-	for _, applier := range matches {
-		ok, err := applier.Apply(ctx, p.options, res.Time)
+	for _, applier := range cluster {
+		ok, err := applier.Apply(ctx, p.options, base)
 		if err != nil {
 			return nil, err
 		}
@@ -117,12 +221,54 @@ func (p *Parser) Parse(text string, base time.Time) (*Result, error) {
 		return nil, nil
 	}
 
-	res.Time, err = ctx.Time(res.Time)
+	t, err := ctx.Time(base, p.options)
 	if err != nil {
 		return nil, errors.Wrap(err, "bind context")
 	}
 
-	return &res, nil
+	return &Result{
+		Index:       left,
+		Text:        clusterText,
+		Time:        t,
+		ZoneUnknown: ctx.ZoneUnknown,
+		Zone:        t.Location(),
+		Ambiguous:   ctx.Ambiguous,
+		Alternative: ctx.Alternative,
+	}, nil
+}
+
+// ParseAny detects a date/time's layout directly from its shape instead of
+// trying a fixed list of time.Parse layouts, and returns the first match
+// found in text. A naive (zone-less) match resolves in base's location.
+func (p *Parser) ParseAny(text string, base time.Time) (*Result, error) {
+	return p.ParseIn(text, base, base.Location())
+}
+
+// ParseIn behaves like ParseAny but resolves a naive match into loc instead
+// of base's location.
+func (p *Parser) ParseIn(text string, base time.Time, loc *time.Location) (*Result, error) {
+	if p.options == nil {
+		p.options = defaultOptions
+	}
+
+	layout, matched := scanLayout(text, p.options.PreferDayFirst)
+	if layout == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation(layout, matched, loc)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse detected layout")
+	}
+
+	idx := strings.Index(text, matched)
+	return &Result{
+		Source: text,
+		Text:   matched,
+		Index:  idx,
+		Time:   t,
+		Zone:   t.Location(),
+	}, nil
 }
 
 // Add adds  given rules to the main chain.
@@ -185,74 +331,89 @@ func init() {
 	NL.Add(common.All...)
 }
 
-// tryStandardTimeFormats attempts to parse the text using Go's standard time formats
-// Returns the parsed time and matched text if successful, nil otherwise
-// If the parsed time doesn't have a timezone, it uses the base time's location
-func tryStandardTimeFormats(text string, base time.Time) (*time.Time, string) {
-	// Trim whitespace
-	trimmed := text
-	for len(trimmed) > 0 && (trimmed[0] == ' ' || trimmed[0] == '\t' || trimmed[0] == '\n') {
-		trimmed = trimmed[1:]
+// tryScannedTimeFormat attempts to parse the whole (trimmed) text using a
+// layout the scanner detects directly from its shape. It only ever claims
+// shapes that lead with a "YYYY-" year (ISO 8601, RFC3339, and similar),
+// which can't be confused with anything a rule handles, so it's safe to
+// try before rule dispatch.
+// A naive (zone-less) match is resolved the same way a rule-based match is
+// - through Context.Time - so DefaultLocation and AmbiguousTimePolicy apply
+// to it too. Returns the populated Result and matched text on success, nil
+// otherwise.
+func tryScannedTimeFormat(p *Parser, text string, base time.Time) (*Result, string) {
+	trimmed := strings.TrimSpace(text)
+
+	layout, matched := scanLayout(trimmed, false)
+	if layout == "" || matched != trimmed || !strings.HasPrefix(layout, "2006-") {
+		return nil, ""
 	}
-	for len(trimmed) > 0 {
-		last := len(trimmed) - 1
-		if trimmed[last] == ' ' || trimmed[last] == '\t' || trimmed[last] == '\n' {
-			trimmed = trimmed[:last]
-		} else {
-			break
+
+	hasZone := strings.HasSuffix(layout, "Z07:00") || strings.HasSuffix(layout, "Z") || strings.HasSuffix(layout, "MST")
+	if hasZone {
+		loc := base.Location()
+		if p.options.DefaultLocation != nil {
+			loc = p.options.DefaultLocation
+		}
+		t, err := time.ParseInLocation(layout, trimmed, loc)
+		if err != nil {
+			return nil, ""
 		}
+		return &Result{Time: t, Zone: t.Location()}, trimmed
 	}
 
-	// Try common standard formats in order of specificity
-	layouts := []string{
-		time.RFC3339Nano,           // 2006-01-02T15:04:05.999999999Z07:00
-		time.RFC3339,                // 2006-01-02T15:04:05Z07:00
-		"2006-01-02T15:04:05-07:00", // RFC3339 with timezone offset
-		"2006-01-02T15:04-07:00",    // RFC3339 without seconds
-		"2006-01-02T15:04:05Z",      // RFC3339 with Z
-		"2006-01-02T15:04:05",       // ISO with T, no timezone
-		"2006-01-02 15:04:05",       // ISO with space (24-hour)
-		"2006-01-02 03:04:05 PM",    // ISO with space and PM (12-hour, hour 1-12)
-		"2006-01-02 03:04:05 AM",    // ISO with space and AM (12-hour, hour 1-12)
-		"2006-01-02 3:04:05 PM",     // ISO with space and PM (12-hour, single digit hour)
-		"2006-01-02 3:04:05 AM",     // ISO with space and AM (12-hour, single digit hour)
-		"2006-01-02 15:04",          // ISO without seconds (24-hour)
-		"2006-01-02 03:04 PM",       // ISO without seconds, PM (12-hour)
-		"2006-01-02 03:04 AM",       // ISO without seconds, AM (12-hour)
-		"2006-01-02",                // Date only
+	parsed, err := time.Parse(layout, trimmed)
+	if err != nil {
+		return nil, ""
 	}
 
-	for _, layout := range layouts {
-		t, err := time.Parse(layout, trimmed)
-		if err == nil {
-			// If the parsed time doesn't have a timezone (location is UTC but no Z or offset),
-			// use the base time's location
-			if t.Location() == time.UTC && layout != time.RFC3339 && layout != time.RFC3339Nano {
-				// Check if layout has timezone info
-				hasTZ := false
-				for _, tzLayout := range []string{time.RFC3339, time.RFC3339Nano, "Z07:00", "-07:00", "+07:00"} {
-					if layout == tzLayout || contains(layout, "Z07:00") || contains(layout, "-07:00") || contains(layout, "+07:00") {
-						hasTZ = true
-						break
-					}
-				}
-				if !hasTZ {
-					// No timezone in layout, use base time's location
-					t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), base.Location())
-				}
-			}
-			return &t, trimmed
-		}
+	year, month, day := parsed.Date()
+	hour, min, sec := parsed.Clock()
+	nsec := parsed.Nanosecond()
+	monthInt := int(month)
+	ctx := &rules.Context{
+		Text:       trimmed,
+		Year:       &year,
+		Month:      &monthInt,
+		Day:        &day,
+		Hour:       &hour,
+		Minute:     &min,
+		Second:     &sec,
+		Nanosecond: &nsec,
 	}
 
-	return nil, ""
+	resolved, err := ctx.Time(base, p.options)
+	if err != nil {
+		return nil, ""
+	}
+
+	return &Result{
+		Time:        resolved,
+		ZoneUnknown: ctx.ZoneUnknown,
+		Zone:        resolved.Location(),
+		Ambiguous:   ctx.Ambiguous,
+		Alternative: ctx.Alternative,
+	}, trimmed
 }
 
-func contains(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+// tryRegisteredLayouts attempts to parse the whole (trimmed) text against
+// p's registered layouts (see RegisterLayout and Parser.AddLayout) for
+// shapes the scanner doesn't classify, such as named months or weekdays.
+// It's tried only after rule-based matching has had a chance to claim the
+// text, so a rule with more precise zone handling (e.g. RFC2822) isn't
+// preempted by a bare time.Parse against an ambiguous layout list.
+func tryRegisteredLayouts(p *Parser, text string, base time.Time) (*time.Time, string) {
+	trimmed := strings.TrimSpace(text)
+
+	loc := base.Location()
+	if p.options.DefaultLocation != nil {
+		loc = p.options.DefaultLocation
+	}
+
+	for _, layout := range p.layoutsFor() {
+		if t, err := time.ParseInLocation(layout, trimmed, loc); err == nil {
+			return &t, trimmed
 		}
 	}
-	return false
+
+	return nil, ""
 }